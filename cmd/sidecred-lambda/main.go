@@ -1,11 +1,9 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
 	"os"
+	"strings"
 
 	environment "github.com/telia-oss/aws-env"
 	"github.com/telia-oss/sidecred"
@@ -13,9 +11,7 @@ import (
 
 	"github.com/alecthomas/kingpin"
 	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
 )
 
 var version string
@@ -23,7 +19,7 @@ var version string
 func main() {
 	var (
 		app    = kingpin.New("sidecred", "Sideload your credentials.").Version(version).Writer(os.Stdout).DefaultEnvars()
-		bucket = app.Flag("config-bucket", "Name of the S3 bucket where the config is stored.").Required().String()
+		bucket = app.Flag("config-bucket", "Name of the S3 bucket where the config is stored, used when config_path has no scheme.").String()
 	)
 
 	sess, err := session.NewSession()
@@ -45,7 +41,10 @@ func main() {
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 }
 
-// Event is the expected payload sent to the Lambda.
+// Event is the expected payload sent to the Lambda. ConfigPath is scheme-qualified
+// (e.g. "s3://bucket/key", "gs://bucket/object", "file:///path", "https://...") so that the same
+// binary can be pointed at any sidecred.ConfigBackend without recompiling. A bare path (no scheme)
+// falls back to the "s3" scheme, using the --config-bucket flag as the bucket.
 type Event struct {
 	Namespace  string `json:"namespace"`
 	ConfigPath string `json:"config_path"`
@@ -70,28 +69,16 @@ func runFunc(configBucket *string) func(*sidecred.Sidecred, sidecred.StateBacken
 	}
 }
 
-func loadConfig(bucket, key string) ([]*sidecred.Request, error) {
-	sess, err := session.NewSession()
-	if err != nil {
-		return nil, err
+func loadConfig(configBucket, configPath string) ([]*sidecred.Request, error) {
+	if !strings.Contains(configPath, "://") {
+		if configBucket == "" {
+			return nil, fmt.Errorf("config_path %q has no scheme and --config-bucket is not set", configPath)
+		}
+		configPath = fmt.Sprintf("s3://%s/%s", configBucket, configPath)
 	}
-	client := s3.New(sess)
-
-	var requests []*sidecred.Request
-	obj, err := client.GetObject(&s3.GetObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-	})
+	backend, err := cli.ConfigBackend(configPath)
 	if err != nil {
 		return nil, err
 	}
-	defer obj.Body.Close()
-	buf := bytes.NewBuffer(nil)
-	if _, err := io.Copy(buf, obj.Body); err != nil {
-		return nil, err
-	}
-	if err := json.Unmarshal(buf.Bytes(), &requests); err != nil {
-		return nil, err
-	}
-	return requests, nil
+	return backend.Load(configPath)
 }
@@ -0,0 +1,49 @@
+// Command sidecred-server runs sidecred as a long-lived HTTP server ("serve" mode), exposing an
+// admin API for on-demand reconciliation and manual intervention, for in-cluster deployments.
+package main
+
+import (
+	"os"
+
+	environment "github.com/telia-oss/aws-env"
+	"github.com/telia-oss/sidecred"
+	"github.com/telia-oss/sidecred/internal/cli"
+	"github.com/telia-oss/sidecred/internal/daemon"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+var version string
+
+func main() {
+	var (
+		app        = kingpin.New("sidecred-server", "Run sidecred as a long-lived admin API server.").Version(version).Writer(os.Stdout).DefaultEnvars()
+		address    = app.Flag("listen-address", "Address to listen for admin API requests on.").Default(":8080").String()
+		adminToken = app.Flag("admin-token", "Shared secret that callers must present as a bearer token to reach the admin API.").Required().String()
+	)
+
+	sess, err := session.NewSession()
+	if err != nil {
+		panic(err)
+	}
+
+	// Exchange secrets in environment variables with their values.
+	env, err := environment.New(sess)
+	if err != nil {
+		panic(err)
+	}
+	if err := env.Populate(); err != nil {
+		panic(err)
+	}
+
+	cli.Setup(app, runFunc(address, adminToken), nil, nil)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+}
+
+func runFunc(address, adminToken *string) func(*sidecred.Sidecred, sidecred.StateBackend) error {
+	return func(s *sidecred.Sidecred, backend sidecred.StateBackend) error {
+		server := daemon.New(s, cli.ConfigBackend, backend, *adminToken)
+		return server.ListenAndServe(*address)
+	}
+}
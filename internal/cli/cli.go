@@ -0,0 +1,140 @@
+// Package cli wires the flags shared by every sidecred entrypoint (sidecred-lambda,
+// sidecred-server) into a sidecred.Sidecred and sidecred.StateBackend: which providers are
+// registered is controlled by which of that provider's credential flags are set, so a deployment
+// only needs to configure the providers it actually uses.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/telia-oss/sidecred"
+	"github.com/telia-oss/sidecred/internal/configstorage"
+	"github.com/telia-oss/sidecred/internal/statestorage"
+	"github.com/telia-oss/sidecred/provider/azuredevops"
+	"github.com/telia-oss/sidecred/provider/bitbucket"
+	"github.com/telia-oss/sidecred/provider/github"
+	"github.com/telia-oss/sidecred/provider/gitlab"
+
+	"github.com/alecthomas/kingpin"
+)
+
+var configBackendOverride string
+
+// ConfigBackend returns the sidecred.ConfigBackend that should load rawPath: the backend forced by
+// --config-backend, if set, otherwise the one inferred from rawPath's own URL scheme. Entrypoints
+// should call this instead of internal/configstorage.New directly, so that --config-backend is
+// honored consistently everywhere a config is loaded.
+func ConfigBackend(rawPath string) (sidecred.ConfigBackend, error) {
+	if configBackendOverride != "" {
+		return configstorage.NewForScheme(configBackendOverride)
+	}
+	return configstorage.New(rawPath)
+}
+
+// Setup registers the flags used to configure every supported provider and the S3 state backend,
+// and wires a kingpin.Application action that constructs a sidecred.Sidecred from whichever
+// providers were configured (plus extraProviders, e.g. providers registered only for testing) and
+// hands it and the state backend to run. githubPolicies authorizes the namespaces listed in it to
+// request Github credentials.
+func Setup(
+	app *kingpin.Application,
+	run func(*sidecred.Sidecred, sidecred.StateBackend) error,
+	extraProviders []sidecred.Provider,
+	githubPolicies map[string]*github.Policy,
+) {
+	var (
+		githubAppID          = app.Flag("github-app-id", "Github App ID; together with --github-private-key-path, enables the Github provider.").Int64()
+		githubPrivateKeyPath = app.Flag("github-private-key-path", "Path to the Github App's PEM-encoded private key.").String()
+		githubPolicyPath     = app.Flag("github-policy-path", "Path to a JSON file mapping namespace to the Github owners/repositories it's authorized to request credentials for, merged with githubPolicies.").String()
+		gitlabToken          = app.Flag("gitlab-token", "GitLab personal access token, enables the GitLab provider.").String()
+		gitlabBaseURL        = app.Flag("gitlab-base-url", "Base URL of the GitLab API.").Default("https://gitlab.com/api/v4").String()
+		bitbucketUsername    = app.Flag("bitbucket-username", "Bitbucket username; together with --bitbucket-app-password, enables the Bitbucket provider.").String()
+		bitbucketAppPassword = app.Flag("bitbucket-app-password", "Bitbucket app password.").String()
+		azureOrganizationURL = app.Flag("azuredevops-organization-url", "Azure DevOps organization URL; together with --azuredevops-token, enables the Azure DevOps provider.").String()
+		azureToken           = app.Flag("azuredevops-token", "Azure DevOps personal access token.").String()
+	)
+	app.Flag("config-backend", "Force this config backend scheme (s3, gs, azblob, file, http, https) instead of inferring it from the config path.").StringVar(&configBackendOverride)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		providers := append([]sidecred.Provider{}, extraProviders...)
+
+		if *gitlabToken != "" {
+			client, err := gitlab.NewClient(*gitlabBaseURL, *gitlabToken)
+			if err != nil {
+				return fmt.Errorf("gitlab: %s", err)
+			}
+			providers = append(providers, gitlab.New(client))
+		}
+
+		if *bitbucketUsername != "" && *bitbucketAppPassword != "" {
+			providers = append(providers, bitbucket.New(bitbucket.NewClient(*bitbucketUsername, *bitbucketAppPassword)))
+		}
+
+		if *azureOrganizationURL != "" && *azureToken != "" {
+			providers = append(providers, azuredevops.New(azuredevops.NewClient(*azureOrganizationURL, *azureToken)))
+		}
+
+		if *githubAppID != 0 && *githubPrivateKeyPath != "" {
+			provider, err := newGithubProvider(*githubAppID, *githubPrivateKeyPath, *githubPolicyPath, githubPolicies)
+			if err != nil {
+				return fmt.Errorf("github: %s", err)
+			}
+			providers = append(providers, provider)
+		}
+
+		return run(sidecred.New(providers...), statestorage.NewS3Backend())
+	})
+}
+
+// newGithubProvider constructs the Github provider, authenticated as the App identified by appID,
+// and authorized by whichever of policies/policyPath's namespace -> Policy mappings are set, as a
+// pre-flight check before it is asked to create or destroy any credential.
+func newGithubProvider(appID int64, privateKeyPath, policyPath string, policies map[string]*github.Policy) (sidecred.Provider, error) {
+	key, err := ioutil.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %s", err)
+	}
+	client, err := github.NewAppsClient(appID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := mergeGithubPolicies(policies, policyPath)
+	if err != nil {
+		return nil, err
+	}
+	if merged == nil {
+		return github.New(client), nil
+	}
+	return github.New(client, github.WithAuthorizer(github.NewAuthorizer(merged))), nil
+}
+
+// mergeGithubPolicies combines policies with any namespace -> Policy mapping loaded from
+// policyPath (the latter taking precedence on a namespace collision), returning nil if neither
+// source has any policies, so that the Github provider is constructed without an Authorizer at
+// all when no policy was configured.
+func mergeGithubPolicies(policies map[string]*github.Policy, policyPath string) (map[string]*github.Policy, error) {
+	var fromFile map[string]*github.Policy
+	if policyPath != "" {
+		raw, err := ioutil.ReadFile(policyPath)
+		if err != nil {
+			return nil, fmt.Errorf("read github policy file: %s", err)
+		}
+		if err := json.Unmarshal(raw, &fromFile); err != nil {
+			return nil, fmt.Errorf("parse github policy file: %s", err)
+		}
+	}
+	if len(policies) == 0 && len(fromFile) == 0 {
+		return nil, nil
+	}
+	merged := make(map[string]*github.Policy, len(policies)+len(fromFile))
+	for namespace, policy := range policies {
+		merged[namespace] = policy
+	}
+	for namespace, policy := range fromFile {
+		merged[namespace] = policy
+	}
+	return merged, nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/telia-oss/sidecred/provider/github"
+)
+
+func TestMergeGithubPoliciesWithNoPolicies(t *testing.T) {
+	merged, err := mergeGithubPolicies(nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if merged != nil {
+		t.Errorf("merged = %+v, want nil", merged)
+	}
+}
+
+func TestMergeGithubPoliciesFileTakesPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policies.json")
+	fromFile := map[string]*github.Policy{
+		"team-a": {AllowedOwners: []string{"from-file"}},
+	}
+	raw, err := json.Marshal(fromFile)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	if err := ioutil.WriteFile(path, raw, 0600); err != nil {
+		t.Fatalf("write file: %s", err)
+	}
+
+	policies := map[string]*github.Policy{
+		"team-a": {AllowedOwners: []string{"from-arg"}},
+		"team-b": {AllowedOwners: []string{"from-arg"}},
+	}
+	merged, err := mergeGithubPolicies(policies, path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]*github.Policy{
+		"team-a": {AllowedOwners: []string{"from-file"}},
+		"team-b": {AllowedOwners: []string{"from-arg"}},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("merged = %+v, want %+v", merged, want)
+	}
+}
@@ -0,0 +1,74 @@
+package configstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/telia-oss/sidecred"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+)
+
+// NewAzureBlobBackend returns a sidecred.ConfigBackend that loads requests from an Azure Blob
+// Storage blob, addressed as "azblob://container/blob". Credentials are read from the
+// AZURE_STORAGE_ACCOUNT and AZURE_STORAGE_ACCESS_KEY environment variables.
+func NewAzureBlobBackend() sidecred.ConfigBackend {
+	return &azureBlobBackend{}
+}
+
+type azureBlobBackend struct{}
+
+// Load implements sidecred.ConfigBackend.
+func (b *azureBlobBackend) Load(path string) ([]*sidecred.Request, error) {
+	container, blob, err := parseAzureBlobPath(path)
+	if err != nil {
+		return nil, err
+	}
+	account := os.Getenv("AZURE_STORAGE_ACCOUNT")
+	key := os.Getenv("AZURE_STORAGE_ACCESS_KEY")
+	credential, err := azblob.NewSharedKeyCredential(account, key)
+	if err != nil {
+		return nil, fmt.Errorf("create azure credential: %s", err)
+	}
+	pipeline := azblob.NewPipeline(credential, azblob.PipelineOptions{})
+
+	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s", account, container, blob))
+	if err != nil {
+		return nil, err
+	}
+	blobURL := azblob.NewBlobURL(*u, pipeline)
+
+	ctx := context.Background()
+	resp, err := blobURL.Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false)
+	if err != nil {
+		return nil, fmt.Errorf("download blob: %s", err)
+	}
+	body := resp.Body(azblob.RetryReaderOptions{})
+	defer body.Close()
+
+	buf := bytes.NewBuffer(nil)
+	if _, err := buf.ReadFrom(body); err != nil {
+		return nil, err
+	}
+	var requests []*sidecred.Request
+	if err := json.Unmarshal(buf.Bytes(), &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func parseAzureBlobPath(path string) (container, blob string, err error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", "", fmt.Errorf("parse azure blob path: %s", err)
+	}
+	if u.Scheme != "azblob" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid azure blob path: %s", path)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
@@ -0,0 +1,40 @@
+// Package configstorage implements sidecred.ConfigBackend for a number of storage schemes, so that
+// requests can be loaded from S3, GCS, Azure Blob Storage, the local filesystem, or an HTTPS URL.
+package configstorage
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/telia-oss/sidecred"
+)
+
+// New returns the sidecred.ConfigBackend appropriate for the scheme of the given path
+// (e.g. "s3://bucket/key", "gs://bucket/object", "file:///path/to/config.json", "https://...").
+func New(rawPath string) (sidecred.ConfigBackend, error) {
+	u, err := url.Parse(rawPath)
+	if err != nil {
+		return nil, fmt.Errorf("parse config path: %s", err)
+	}
+	return NewForScheme(u.Scheme)
+}
+
+// NewForScheme returns the sidecred.ConfigBackend for an explicit scheme, bypassing the URL-based
+// inference New does. Used by internal/cli's --config-backend flag to force a backend instead of
+// inferring one from the config path.
+func NewForScheme(scheme string) (sidecred.ConfigBackend, error) {
+	switch scheme {
+	case "s3":
+		return NewS3Backend(), nil
+	case "gs":
+		return NewGCSBackend(), nil
+	case "azblob":
+		return NewAzureBlobBackend(), nil
+	case "file":
+		return NewFileBackend(), nil
+	case "http", "https":
+		return NewHTTPSBackend(), nil
+	default:
+		return nil, fmt.Errorf("unsupported config backend scheme: %q", scheme)
+	}
+}
@@ -0,0 +1,70 @@
+package configstorage
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/telia-oss/sidecred"
+)
+
+func TestNewDispatchesOnScheme(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    sidecred.ConfigBackend
+		wantErr bool
+	}{
+		{path: "s3://bucket/key", want: &s3Backend{}},
+		{path: "gs://bucket/object", want: &gcsBackend{}},
+		{path: "azblob://container/blob", want: &azureBlobBackend{}},
+		{path: "file:///tmp/config.json", want: &fileBackend{}},
+		{path: "https://example.com/config.json", want: &httpsBackend{}},
+		{path: "ftp://example.com/config.json", wantErr: true},
+	}
+	for _, tc := range tests {
+		backend, err := New(tc.path)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("New(%q): expected an error", tc.path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("New(%q): unexpected error: %s", tc.path, err)
+		}
+		if got, want := reflect.TypeOf(backend), reflect.TypeOf(tc.want); got != want {
+			t.Errorf("New(%q) = %s, want %s", tc.path, got, want)
+		}
+	}
+}
+
+func TestFileBackendLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	requests := []*sidecred.Request{{Type: sidecred.Github}}
+	raw, err := json.Marshal(requests)
+	if err != nil {
+		t.Fatalf("marshal requests: %s", err)
+	}
+	if err := ioutil.WriteFile(path, raw, 0600); err != nil {
+		t.Fatalf("write file: %s", err)
+	}
+
+	backend := NewFileBackend()
+	got, err := backend.Load("file://" + path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 1 || got[0].Type != sidecred.Github {
+		t.Errorf("Load() = %+v, want one request of type %s", got, sidecred.Github)
+	}
+}
+
+func TestFileBackendLoadMissingFile(t *testing.T) {
+	backend := NewFileBackend()
+	if _, err := backend.Load("file:///no/such/file.json"); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,35 @@
+package configstorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/telia-oss/sidecred"
+)
+
+// NewFileBackend returns a sidecred.ConfigBackend that loads requests from a local file,
+// addressed as "file:///path/to/config.json".
+func NewFileBackend() sidecred.ConfigBackend {
+	return &fileBackend{}
+}
+
+type fileBackend struct{}
+
+// Load implements sidecred.ConfigBackend.
+func (b *fileBackend) Load(path string) ([]*sidecred.Request, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("parse file path: %s", err)
+	}
+	raw, err := ioutil.ReadFile(u.Path)
+	if err != nil {
+		return nil, err
+	}
+	var requests []*sidecred.Request
+	if err := json.Unmarshal(raw, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
@@ -0,0 +1,63 @@
+package configstorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/telia-oss/sidecred"
+
+	"cloud.google.com/go/storage"
+)
+
+// NewGCSBackend returns a sidecred.ConfigBackend that loads requests from a GCS object,
+// addressed as "gs://bucket/object".
+func NewGCSBackend() sidecred.ConfigBackend {
+	return &gcsBackend{}
+}
+
+type gcsBackend struct{}
+
+// Load implements sidecred.ConfigBackend.
+func (b *gcsBackend) Load(path string) ([]*sidecred.Request, error) {
+	bucket, object, err := parseGCSPath(path)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create gcs client: %s", err)
+	}
+	defer client.Close()
+
+	r, err := client.Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open gcs object: %s", err)
+	}
+	defer r.Close()
+
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var requests []*sidecred.Request
+	if err := json.Unmarshal(raw, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func parseGCSPath(path string) (bucket, object string, err error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", "", fmt.Errorf("parse gcs path: %s", err)
+	}
+	if u.Scheme != "gs" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid gcs path: %s", path)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
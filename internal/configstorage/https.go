@@ -0,0 +1,40 @@
+package configstorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/telia-oss/sidecred"
+)
+
+// NewHTTPSBackend returns a sidecred.ConfigBackend that loads requests from an HTTP(S) URL.
+func NewHTTPSBackend() sidecred.ConfigBackend {
+	return &httpsBackend{client: http.DefaultClient}
+}
+
+type httpsBackend struct {
+	client *http.Client
+}
+
+// Load implements sidecred.ConfigBackend.
+func (b *httpsBackend) Load(path string) ([]*sidecred.Request, error) {
+	resp, err := b.client.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var requests []*sidecred.Request
+	if err := json.Unmarshal(raw, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
@@ -0,0 +1,225 @@
+// Package daemon implements a long-running HTTP server that runs sidecred as an in-cluster service,
+// exposing an admin API for on-demand reconciliation and manual intervention.
+package daemon
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/telia-oss/sidecred"
+)
+
+// ConfigBackendFactory resolves a scheme-qualified config path to the sidecred.ConfigBackend
+// that should be used to load it (e.g. internal/configstorage.New).
+type ConfigBackendFactory func(configPath string) (sidecred.ConfigBackend, error)
+
+// Server is a long-running HTTP server that exposes an admin API for sidecred.
+type Server struct {
+	sidecred      *sidecred.Sidecred
+	configBackend ConfigBackendFactory
+	stateBackend  sidecred.StateBackend
+	adminToken    string
+	metrics       *metrics
+}
+
+// New returns a new Server, reusing the same Sidecred instance and state backend construction
+// as the CLI/Lambda entrypoints. adminToken is the shared secret that callers must present as a
+// bearer token to reach any endpoint other than /healthz; New panics if it is empty, since an
+// admin API with no authentication must never be started.
+func New(s *sidecred.Sidecred, configBackend ConfigBackendFactory, stateBackend sidecred.StateBackend, adminToken string) *Server {
+	if adminToken == "" {
+		panic("daemon: adminToken must not be empty")
+	}
+	return &Server{
+		sidecred:      s,
+		configBackend: configBackend,
+		stateBackend:  stateBackend,
+		adminToken:    adminToken,
+		metrics:       newMetrics(),
+	}
+}
+
+// ListenAndServe starts the admin API on the given address, blocking until it returns an error.
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s.handler())
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/reconcile", s.requireAuth(s.handleReconcile))
+	mux.HandleFunc("/resources", s.requireAuth(s.handleResources))
+	mux.HandleFunc("/resources/", s.requireAuth(s.handleResources))
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.Handle("/metrics", s.requireAuth(metricsHandler().ServeHTTP))
+	return mux
+}
+
+// requireAuth rejects requests that do not present the configured admin token as a bearer token,
+// so that the admin API cannot be driven by anyone who can merely reach the listener.
+func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(header, "Bearer ")
+		if token == "" || token == header || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// reconcileRequest is the expected payload for POST /reconcile.
+type reconcileRequest struct {
+	Namespace  string `json:"namespace"`
+	ConfigPath string `json:"config_path"`
+	StatePath  string `json:"state_path"`
+}
+
+func (s *Server) handleReconcile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req reconcileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("decode request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	configBackend, err := s.configBackend(req.ConfigPath)
+	if err != nil {
+		s.metrics.providerError.WithLabelValues("daemon").Inc()
+		http.Error(w, fmt.Sprintf("resolve config backend: %s", err), http.StatusBadRequest)
+		return
+	}
+	requests, err := configBackend.Load(req.ConfigPath)
+	if err != nil {
+		s.metrics.providerError.WithLabelValues("daemon").Inc()
+		http.Error(w, fmt.Sprintf("load config: %s", err), http.StatusInternalServerError)
+		return
+	}
+	state, err := s.stateBackend.Load(req.StatePath)
+	if err != nil {
+		s.metrics.providerError.WithLabelValues("daemon").Inc()
+		http.Error(w, fmt.Sprintf("load state: %s", err), http.StatusInternalServerError)
+		return
+	}
+	before := snapshotResources(state)
+
+	if err := s.sidecred.Process(req.Namespace, requests, state); err != nil {
+		s.metrics.providerError.WithLabelValues("daemon").Inc()
+		http.Error(w, fmt.Sprintf("process requests: %s", err), http.StatusInternalServerError)
+		return
+	}
+	if err := s.stateBackend.Save(req.StatePath, state); err != nil {
+		http.Error(w, fmt.Sprintf("save state: %s", err), http.StatusInternalServerError)
+		return
+	}
+	s.recordReconcile(before, state)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleResources(w http.ResponseWriter, r *http.Request) {
+	statePath := r.URL.Query().Get("state_path")
+	if statePath == "" {
+		http.Error(w, "state_path is required", http.StatusBadRequest)
+		return
+	}
+	state, err := s.stateBackend.Load(statePath)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("load state: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.recordExpiry(state)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(state.Resources)
+	case http.MethodDelete:
+		id := strings.TrimPrefix(r.URL.Path, "/resources/")
+		if id == "" {
+			http.Error(w, "resource id is required", http.StatusBadRequest)
+			return
+		}
+		if !forceDestroy(state, id) {
+			http.Error(w, "resource not found", http.StatusNotFound)
+			return
+		}
+		if err := s.stateBackend.Save(statePath, state); err != nil {
+			http.Error(w, fmt.Sprintf("save state: %s", err), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// forceDestroy marks the resource with the given ID as expired, so that the next reconciliation
+// cycle destroys and recreates it.
+func forceDestroy(state *sidecred.State, id string) bool {
+	for _, resource := range state.Resources {
+		if resource.ID == id {
+			resource.Expiration = time.Now().UTC()
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) recordExpiry(state *sidecred.State) {
+	for _, resource := range state.Resources {
+		s.metrics.expiry.WithLabelValues(resource.ID).Set(time.Until(resource.Expiration).Seconds())
+	}
+}
+
+// resourceSnapshot is the subset of a resource's state tracked across a Process call, so that its
+// effect on state can be diffed afterwards.
+type resourceSnapshot struct {
+	provider   string
+	expiration time.Time
+}
+
+// snapshotResources captures the state of every resource in state, keyed by ID.
+func snapshotResources(state *sidecred.State) map[string]resourceSnapshot {
+	snapshot := make(map[string]resourceSnapshot, len(state.Resources))
+	for _, resource := range state.Resources {
+		snapshot[resource.ID] = resourceSnapshot{provider: string(resource.Type), expiration: resource.Expiration}
+	}
+	return snapshot
+}
+
+// recordReconcile compares the resources in state before and after a Process call and increments
+// the creates/destroys/rotations counters accordingly: a resource ID present only after is a
+// create, present only before is a destroy, and present in both with a different expiration is a
+// rotation.
+func (s *Server) recordReconcile(before map[string]resourceSnapshot, state *sidecred.State) {
+	after := make(map[string]bool, len(state.Resources))
+	for _, resource := range state.Resources {
+		after[resource.ID] = true
+		provider := string(resource.Type)
+		previous, existed := before[resource.ID]
+		switch {
+		case !existed:
+			s.metrics.creates.WithLabelValues(provider).Inc()
+		case !previous.expiration.Equal(resource.Expiration):
+			s.metrics.rotations.WithLabelValues(provider).Inc()
+		}
+	}
+	for id, snapshot := range before {
+		if !after[id] {
+			s.metrics.destroys.WithLabelValues(snapshot.provider).Inc()
+		}
+	}
+	s.recordExpiry(state)
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
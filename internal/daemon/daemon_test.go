@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/telia-oss/sidecred"
+)
+
+// newTestServer returns a Server backed by a fresh set of metrics, without touching the global
+// Prometheus registry (newMetrics registers there, and MustRegister panics on the second call).
+func newTestServer(adminToken string) *Server {
+	return &Server{
+		adminToken: adminToken,
+		metrics: &metrics{
+			creates:       prometheus.NewCounterVec(prometheus.CounterOpts{Name: "creates"}, []string{"provider"}),
+			destroys:      prometheus.NewCounterVec(prometheus.CounterOpts{Name: "destroys"}, []string{"provider"}),
+			rotations:     prometheus.NewCounterVec(prometheus.CounterOpts{Name: "rotations"}, []string{"provider"}),
+			providerError: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "provider_error"}, []string{"provider"}),
+			expiry:        prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "expiry"}, []string{"credential"}),
+		},
+	}
+}
+
+func TestRequireAuth(t *testing.T) {
+	server := newTestServer("secret")
+	handler := server.requireAuth(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		authHeader string
+		wantStatus int
+	}{
+		{name: "missing token", authHeader: "", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", authHeader: "Bearer nope", wantStatus: http.StatusUnauthorized},
+		{name: "correct token", authHeader: "Bearer secret", wantStatus: http.StatusOK},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/resources", nil)
+		if tc.authHeader != "" {
+			req.Header.Set("Authorization", tc.authHeader)
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		if rec.Code != tc.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tc.name, rec.Code, tc.wantStatus)
+		}
+	}
+}
+
+func TestHandleHealthzIsNotAuthenticated(t *testing.T) {
+	server := newTestServer("secret")
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	server.handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /healthz = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestRecordReconcile(t *testing.T) {
+	server := newTestServer("secret")
+	now := time.Now()
+
+	before := map[string]resourceSnapshot{
+		"destroyed": {provider: string(sidecred.Github), expiration: now},
+		"rotated":   {provider: string(sidecred.Github), expiration: now},
+	}
+	state := &sidecred.State{
+		Resources: []*sidecred.Resource{
+			{ID: "rotated", Type: sidecred.Github, Expiration: now.Add(time.Hour)},
+			{ID: "created", Type: sidecred.Github, Expiration: now.Add(time.Hour)},
+		},
+	}
+
+	server.recordReconcile(before, state)
+
+	if got := testutil.ToFloat64(server.metrics.creates.WithLabelValues(string(sidecred.Github))); got != 1 {
+		t.Errorf("creates = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(server.metrics.rotations.WithLabelValues(string(sidecred.Github))); got != 1 {
+		t.Errorf("rotations = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(server.metrics.destroys.WithLabelValues(string(sidecred.Github))); got != 1 {
+		t.Errorf("destroys = %v, want 1", got)
+	}
+}
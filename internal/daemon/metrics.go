@@ -0,0 +1,54 @@
+package daemon
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsHandler returns the HTTP handler that serves the registered Prometheus collectors.
+func metricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// metrics are the Prometheus collectors exposed by the daemon on /metrics.
+type metrics struct {
+	creates       *prometheus.CounterVec
+	destroys      *prometheus.CounterVec
+	rotations     *prometheus.CounterVec
+	providerError *prometheus.CounterVec
+	expiry        *prometheus.GaugeVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		creates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "sidecred",
+			Name:      "credentials_created_total",
+			Help:      "Number of credentials created, by provider.",
+		}, []string{"provider"}),
+		destroys: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "sidecred",
+			Name:      "credentials_destroyed_total",
+			Help:      "Number of credentials destroyed, by provider.",
+		}, []string{"provider"}),
+		rotations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "sidecred",
+			Name:      "credentials_rotated_total",
+			Help:      "Number of credentials rotated, by provider.",
+		}, []string{"provider"}),
+		providerError: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "sidecred",
+			Name:      "provider_errors_total",
+			Help:      "Number of failed reconciliations (config or state loading, or request processing); every error is labelled provider=\"daemon\".",
+		}, []string{"provider"}),
+		expiry: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "sidecred",
+			Name:      "credential_expiry_seconds",
+			Help:      "Seconds until a tracked credential expires, by resource ID.",
+		}, []string{"credential"}),
+	}
+	prometheus.MustRegister(m.creates, m.destroys, m.rotations, m.providerError, m.expiry)
+	return m
+}
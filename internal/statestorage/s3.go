@@ -0,0 +1,91 @@
+// Package statestorage implements sidecred.StateBackend backed by an S3 object, the storage used
+// by every sidecred entrypoint set up via internal/cli.
+package statestorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/telia-oss/sidecred"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// NewS3Backend returns a sidecred.StateBackend that loads and saves state to an S3 object,
+// addressed as "s3://bucket/key".
+func NewS3Backend() sidecred.StateBackend {
+	return &s3Backend{}
+}
+
+type s3Backend struct{}
+
+// Load implements sidecred.StateBackend.
+func (b *s3Backend) Load(path string) (*sidecred.State, error) {
+	bucket, key, err := parseS3Path(path)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	client := s3.New(sess)
+
+	obj, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Body.Close()
+	buf := bytes.NewBuffer(nil)
+	if _, err := io.Copy(buf, obj.Body); err != nil {
+		return nil, err
+	}
+	var state sidecred.State
+	if err := json.Unmarshal(buf.Bytes(), &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save implements sidecred.StateBackend.
+func (b *s3Backend) Save(path string, state *sidecred.State) error {
+	bucket, key, err := parseS3Path(path)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	sess, err := session.NewSession()
+	if err != nil {
+		return err
+	}
+	client := s3.New(sess)
+	_, err = client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(raw),
+	})
+	return err
+}
+
+func parseS3Path(path string) (bucket, key string, err error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", "", fmt.Errorf("parse s3 path: %s", err)
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid s3 path: %s", path)
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), nil
+}
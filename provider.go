@@ -0,0 +1,32 @@
+package sidecred
+
+// ProviderType identifies the provider responsible for a Request or Resource. A provider that
+// handles more than one kind of credential (e.g. Github access tokens and deploy keys) reports a
+// single coarse Type for routing/registration purposes, distinct from the finer-grained types used
+// internally by its Create/Destroy dispatch.
+type ProviderType string
+
+// Supported provider types.
+const (
+	Github               ProviderType = "github"
+	GithubAccessToken    ProviderType = "github:access-token"
+	GithubDeployKey      ProviderType = "github:deploy-key"
+	Gitlab               ProviderType = "gitlab"
+	GitlabAccessToken    ProviderType = "gitlab:access-token"
+	GitlabDeployKey      ProviderType = "gitlab:deploy-key"
+	BitbucketAccessToken ProviderType = "bitbucket:access-token"
+	AzureDevopsPAT       ProviderType = "azuredevops:pat"
+)
+
+// Provider creates and destroys the credentials for a Request/Resource of its ProviderType.
+type Provider interface {
+	// Type returns the ProviderType that Sidecred registers this Provider under.
+	Type() ProviderType
+
+	// Create mints the credential(s) described by request, returning provider-specific metadata
+	// to be persisted on the resulting Resource so that Destroy can later tear it down.
+	Create(request *Request) ([]*Credential, *Metadata, error)
+
+	// Destroy tears down the credential tracked by resource.
+	Destroy(resource *Resource) error
+}
@@ -0,0 +1,98 @@
+// Package azuredevops implements a sidecred.Provider for Azure DevOps personal access tokens (PATs).
+// A PAT is created in the organization of the client's organizationURL: a request may name at most
+// one organization, and that name is not otherwise used. PATs are not scoped to individual projects.
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/telia-oss/sidecred"
+)
+
+// PATRequestConfig ...
+type PATRequestConfig struct {
+	DisplayName   string   `json:"display_name"`
+	Organizations []string `json:"organizations"`
+	Scopes        []string `json:"scopes"`
+}
+
+// New returns a new sidecred.Provider for Azure DevOps credentials.
+func New(client ClientAPI, options ...option) sidecred.Provider {
+	p := &provider{
+		client:                client,
+		tokenRotationInterval: time.Duration(time.Hour * 24 * 30),
+	}
+	for _, optionFunc := range options {
+		optionFunc(p)
+	}
+	return p
+}
+
+type option func(*provider)
+
+// WithTokenRotationInterval sets the interval at which PATs should be rotated.
+func WithTokenRotationInterval(duration time.Duration) option {
+	return func(p *provider) {
+		p.tokenRotationInterval = duration
+	}
+}
+
+// Implements sidecred.Provider for Azure DevOps credentials.
+type provider struct {
+	client                ClientAPI
+	tokenRotationInterval time.Duration
+}
+
+// Type implements sidecred.Provider.
+func (p *provider) Type() sidecred.ProviderType {
+	return sidecred.AzureDevopsPAT
+}
+
+// Create implements sidecred.Provider.
+func (p *provider) Create(request *sidecred.Request) ([]*sidecred.Credential, *sidecred.Metadata, error) {
+	switch request.Type {
+	case sidecred.AzureDevopsPAT:
+		return p.createPAT(request)
+	}
+	return nil, nil, fmt.Errorf("invalid request: %s", request.Type)
+}
+
+func (p *provider) createPAT(request *sidecred.Request) ([]*sidecred.Credential, *sidecred.Metadata, error) {
+	var c PATRequestConfig
+	if err := request.UnmarshalConfig(&c); err != nil {
+		return nil, nil, err
+	}
+	expiration := time.Now().Add(p.tokenRotationInterval).UTC()
+	pat, err := p.client.CreatePAT(c.DisplayName, c.Scopes, c.Organizations, expiration)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create pat: %s", err)
+	}
+	metadata := &sidecred.Metadata{"authorization_id": pat.AuthorizationID}
+	return []*sidecred.Credential{{
+		Name:        c.DisplayName + "-pat",
+		Value:       pat.Token,
+		Description: "Azure DevOps PAT managed by sidecred.",
+		Expiration:  expiration,
+	}}, metadata, nil
+}
+
+// Destroy implements sidecred.Provider.
+func (p *provider) Destroy(resource *sidecred.Resource) error {
+	var c PATRequestConfig
+	if err := json.Unmarshal(resource.Config, &c); err != nil {
+		return fmt.Errorf("unmarshal resource config: %s", err)
+	}
+	if resource.Metadata == nil {
+		return nil
+	}
+	id := (*resource.Metadata)["authorization_id"]
+	if id == "" {
+		return nil
+	}
+	if err := p.client.RevokePAT(id); err != nil {
+		return fmt.Errorf("revoke pat: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/telia-oss/sidecred"
+)
+
+type fakeClient struct {
+	create func(displayName string, scopes, organizations []string, validTo time.Time) (*PAT, error)
+	revoke func(authorizationID string) error
+}
+
+func (f *fakeClient) CreatePAT(displayName string, scopes, organizations []string, validTo time.Time) (*PAT, error) {
+	return f.create(displayName, scopes, organizations, validTo)
+}
+
+func (f *fakeClient) RevokePAT(authorizationID string) error {
+	return f.revoke(authorizationID)
+}
+
+func TestProviderType(t *testing.T) {
+	p := New(&fakeClient{})
+	if p.Type() != sidecred.AzureDevopsPAT {
+		t.Errorf("Type() = %s, want %s", p.Type(), sidecred.AzureDevopsPAT)
+	}
+}
+
+func TestCreatePATSetsExpiration(t *testing.T) {
+	var gotValidTo time.Time
+	client := &fakeClient{
+		create: func(displayName string, scopes, organizations []string, validTo time.Time) (*PAT, error) {
+			gotValidTo = validTo
+			return &PAT{AuthorizationID: "auth-1", Token: "token"}, nil
+		},
+	}
+	p := New(client)
+
+	config, _ := json.Marshal(&PATRequestConfig{DisplayName: "ci", Organizations: []string{"acme"}})
+	creds, metadata, err := p.Create(&sidecred.Request{Type: sidecred.AzureDevopsPAT, Config: config})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotValidTo.IsZero() {
+		t.Error("expected a non-zero validTo to be passed to CreatePAT")
+	}
+	if creds[0].Expiration.IsZero() {
+		t.Error("expected credential Expiration to be set")
+	}
+	if (*metadata)["authorization_id"] != "auth-1" {
+		t.Errorf("authorization_id = %s, want auth-1", (*metadata)["authorization_id"])
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	var revokedID string
+	client := &fakeClient{
+		revoke: func(authorizationID string) error {
+			revokedID = authorizationID
+			return nil
+		},
+	}
+	p := New(client)
+
+	config, _ := json.Marshal(&PATRequestConfig{DisplayName: "ci"})
+	resource := &sidecred.Resource{Config: config, Metadata: &sidecred.Metadata{"authorization_id": "auth-1"}}
+	if err := p.Destroy(resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if revokedID != "auth-1" {
+		t.Errorf("revoked id = %s, want auth-1", revokedID)
+	}
+}
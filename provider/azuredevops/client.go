@@ -0,0 +1,98 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const apiVersion = "7.1-preview.1"
+
+// NewClient returns a ClientAPI that authenticates against the Azure DevOps PAT Lifecycle
+// Management API using the given personal access token. organizationURL is the base URL of the
+// organization used to host the API calls (e.g. "https://vssps.dev.azure.com/my-org"). The API has
+// no way to scope a single PAT to an explicit subset of organizations: it's either "the current
+// organization" or "all organizations the account can reach" (allOrgs). CreatePAT refuses requests
+// for more than one organization rather than silently broadening the credential to allOrgs.
+func NewClient(organizationURL, personalAccessToken string) ClientAPI {
+	return &client{
+		organizationURL: strings.TrimSuffix(organizationURL, "/"),
+		token:           personalAccessToken,
+		http:            http.DefaultClient,
+	}
+}
+
+type client struct {
+	organizationURL string
+	token           string
+	http            *http.Client
+}
+
+type createPATRequest struct {
+	DisplayName string    `json:"displayName"`
+	Scope       string    `json:"scope"`
+	ValidTo     time.Time `json:"validTo"`
+	AllOrgs     bool      `json:"allOrgs"`
+}
+
+// CreatePAT implements ClientAPI.
+func (c *client) CreatePAT(displayName string, scopes []string, organizations []string, validTo time.Time) (*PAT, error) {
+	if len(organizations) > 1 {
+		return nil, fmt.Errorf("cannot scope a PAT to an explicit subset of organizations (%v): the Azure DevOps API only supports the current organization or all organizations", organizations)
+	}
+	body, err := json.Marshal(&createPATRequest{
+		DisplayName: displayName,
+		Scope:       strings.Join(scopes, " "),
+		ValidTo:     validTo,
+		AllOrgs:     false,
+	})
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/_apis/tokens/pats?api-version=%s", c.organizationURL, apiVersion)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth("", c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	var out struct {
+		PatToken PAT `json:"patToken"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out.PatToken, nil
+}
+
+// RevokePAT implements ClientAPI.
+func (c *client) RevokePAT(authorizationID string) error {
+	url := fmt.Sprintf("%s/_apis/tokens/pats?authorizationId=%s&api-version=%s", c.organizationURL, authorizationID, apiVersion)
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("", c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package azuredevops
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreatePATRejectsMultipleOrganizations(t *testing.T) {
+	client := NewClient("https://vssps.dev.azure.com/my-org", "token")
+	_, err := client.CreatePAT("ci", []string{"vso.code"}, []string{"org-a", "org-b"}, time.Now().Add(time.Hour))
+	if err == nil {
+		t.Fatal("expected an error when scoping a PAT to more than one organization")
+	}
+}
@@ -0,0 +1,15 @@
+package azuredevops
+
+import "time"
+
+// ClientAPI is the subset of the Azure DevOps Personal Access Tokens Administration API used by this provider.
+type ClientAPI interface {
+	CreatePAT(displayName string, scopes []string, organizations []string, validTo time.Time) (*PAT, error)
+	RevokePAT(authorizationID string) error
+}
+
+// PAT is the subset of the Azure DevOps PAT response that sidecred cares about.
+type PAT struct {
+	AuthorizationID string `json:"authorizationId"`
+	Token           string `json:"token"`
+}
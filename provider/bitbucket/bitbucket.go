@@ -0,0 +1,77 @@
+// Package bitbucket implements a sidecred.Provider for Bitbucket Cloud repository access tokens.
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/telia-oss/sidecred"
+)
+
+// AccessTokenRequestConfig ...
+type AccessTokenRequestConfig struct {
+	Workspace  string   `json:"workspace"`
+	Repository string   `json:"repository"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+}
+
+// New returns a new sidecred.Provider for Bitbucket credentials.
+func New(client ClientAPI) sidecred.Provider {
+	return &provider{client: client}
+}
+
+// Implements sidecred.Provider for Bitbucket credentials.
+type provider struct {
+	client ClientAPI
+}
+
+// Type implements sidecred.Provider.
+func (p *provider) Type() sidecred.ProviderType {
+	return sidecred.BitbucketAccessToken
+}
+
+// Create implements sidecred.Provider.
+func (p *provider) Create(request *sidecred.Request) ([]*sidecred.Credential, *sidecred.Metadata, error) {
+	switch request.Type {
+	case sidecred.BitbucketAccessToken:
+		return p.createAccessToken(request)
+	}
+	return nil, nil, fmt.Errorf("invalid request: %s", request.Type)
+}
+
+func (p *provider) createAccessToken(request *sidecred.Request) ([]*sidecred.Credential, *sidecred.Metadata, error) {
+	var c AccessTokenRequestConfig
+	if err := request.UnmarshalConfig(&c); err != nil {
+		return nil, nil, err
+	}
+	token, err := p.client.CreateRepositoryAccessToken(c.Workspace, c.Repository, c.Name, c.Scopes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create repository access token: %s", err)
+	}
+	metadata := &sidecred.Metadata{"token_uuid": token.UUID}
+	return []*sidecred.Credential{{
+		Name:        c.Workspace + "-" + c.Repository + "-access-token",
+		Value:       token.Token,
+		Description: "Bitbucket repository access token managed by sidecred.",
+	}}, metadata, nil
+}
+
+// Destroy implements sidecred.Provider.
+func (p *provider) Destroy(resource *sidecred.Resource) error {
+	var c AccessTokenRequestConfig
+	if err := json.Unmarshal(resource.Config, &c); err != nil {
+		return fmt.Errorf("unmarshal resource config: %s", err)
+	}
+	if resource.Metadata == nil {
+		return nil
+	}
+	uuid := (*resource.Metadata)["token_uuid"]
+	if uuid == "" {
+		return nil
+	}
+	if err := p.client.DeleteRepositoryAccessToken(c.Workspace, c.Repository, uuid); err != nil {
+		return fmt.Errorf("delete repository access token: %s", err)
+	}
+	return nil
+}
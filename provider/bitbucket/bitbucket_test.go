@@ -0,0 +1,69 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/telia-oss/sidecred"
+)
+
+type fakeClient struct {
+	create func(workspace, repoSlug, name string, scopes []string) (*AccessToken, error)
+	delete func(workspace, repoSlug, tokenID string) error
+}
+
+func (f *fakeClient) CreateRepositoryAccessToken(workspace, repoSlug, name string, scopes []string) (*AccessToken, error) {
+	return f.create(workspace, repoSlug, name, scopes)
+}
+
+func (f *fakeClient) DeleteRepositoryAccessToken(workspace, repoSlug, tokenID string) error {
+	return f.delete(workspace, repoSlug, tokenID)
+}
+
+func TestProviderType(t *testing.T) {
+	p := New(&fakeClient{})
+	if p.Type() != sidecred.BitbucketAccessToken {
+		t.Errorf("Type() = %s, want %s", p.Type(), sidecred.BitbucketAccessToken)
+	}
+}
+
+func TestCreateAccessToken(t *testing.T) {
+	client := &fakeClient{
+		create: func(workspace, repoSlug, name string, scopes []string) (*AccessToken, error) {
+			return &AccessToken{UUID: "uuid-1", Token: "token"}, nil
+		},
+	}
+	p := New(client)
+
+	config, _ := json.Marshal(&AccessTokenRequestConfig{Workspace: "acme", Repository: "api", Name: "ci"})
+	creds, metadata, err := p.Create(&sidecred.Request{Type: sidecred.BitbucketAccessToken, Config: config})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if creds[0].Value != "token" {
+		t.Errorf("Value = %s, want token", creds[0].Value)
+	}
+	if (*metadata)["token_uuid"] != "uuid-1" {
+		t.Errorf("token_uuid = %s, want uuid-1", (*metadata)["token_uuid"])
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	var deletedID string
+	client := &fakeClient{
+		delete: func(workspace, repoSlug, tokenID string) error {
+			deletedID = tokenID
+			return nil
+		},
+	}
+	p := New(client)
+
+	config, _ := json.Marshal(&AccessTokenRequestConfig{Workspace: "acme", Repository: "api"})
+	resource := &sidecred.Resource{Config: config, Metadata: &sidecred.Metadata{"token_uuid": "uuid-1"}}
+	if err := p.Destroy(resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if deletedID != "uuid-1" {
+		t.Errorf("deleted id = %s, want uuid-1", deletedID)
+	}
+}
@@ -0,0 +1,80 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const baseURL = "https://api.bitbucket.org/2.0"
+
+// NewClient returns a ClientAPI that authenticates against the Bitbucket Cloud API using the given
+// workspace app password (username and app password, as required by the "repository access tokens" API).
+func NewClient(username, appPassword string) ClientAPI {
+	return &client{
+		username:    username,
+		appPassword: appPassword,
+		http:        http.DefaultClient,
+	}
+}
+
+type client struct {
+	username    string
+	appPassword string
+	http        *http.Client
+}
+
+type createAccessTokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreateRepositoryAccessToken implements ClientAPI.
+func (c *client) CreateRepositoryAccessToken(workspace, repoSlug, name string, scopes []string) (*AccessToken, error) {
+	body, err := json.Marshal(&createAccessTokenRequest{Name: name, Scopes: scopes})
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/repositories/%s/%s/access-tokens", baseURL, workspace, repoSlug)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.appPassword)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	var token AccessToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteRepositoryAccessToken implements ClientAPI.
+func (c *client) DeleteRepositoryAccessToken(workspace, repoSlug, tokenID string) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/access-tokens/%s", baseURL, workspace, repoSlug, tokenID)
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.username, c.appPassword)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package bitbucket
+
+// ClientAPI is the subset of the Bitbucket Cloud REST API that is used by this provider.
+type ClientAPI interface {
+	CreateRepositoryAccessToken(workspace, repoSlug, name string, scopes []string) (*AccessToken, error)
+	DeleteRepositoryAccessToken(workspace, repoSlug, tokenID string) error
+}
+
+// AccessToken is the subset of the Bitbucket "repository access token" response that sidecred cares about.
+type AccessToken struct {
+	UUID  string `json:"uuid"`
+	Token string `json:"access_token"`
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v28/github"
+)
+
+// app wraps the Github Apps API and resolves installation IDs and repository IDs for
+// organizations/users.
+type app struct {
+	client                    AppsAPI
+	installationClientFactory func(token string) InstallationAPI
+	installationID            map[string]int64
+}
+
+// newApp returns a new app that wraps the given AppsAPI client. installationClientFactory is used
+// to resolve repository names to IDs when a request scopes a token down to specific repositories.
+func newApp(client AppsAPI, installationClientFactory func(token string) InstallationAPI) *app {
+	return &app{
+		client:                    client,
+		installationClientFactory: installationClientFactory,
+		installationID:            make(map[string]int64),
+	}
+}
+
+// createInstallationToken creates an installation token for the given owner, optionally scoped down to the
+// given permissions and repositories. A nil permissions or empty repositories argument requests the
+// installation's default, unrestricted access.
+func (a *app) createInstallationToken(owner string, permissions *github.InstallationPermissions, repositories []string) (string, time.Time, error) {
+	id, err := a.findInstallationID(owner)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("find installation: %s", err)
+	}
+	opts := &github.InstallationTokenOptions{
+		Permissions: permissions,
+	}
+	if len(repositories) > 0 {
+		ids, err := a.resolveRepositoryIDs(id, repositories)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("resolve repository ids: %s", err)
+		}
+		opts.RepositoryIDs = ids
+	}
+	token, _, err := a.client.CreateInstallationToken(context.TODO(), id, opts)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("create installation token: %s", err)
+	}
+	return token.GetToken(), token.GetExpiresAt(), nil
+}
+
+// resolveRepositoryIDs resolves the given repository names (belonging to the installation with the
+// given ID) to their numeric IDs, as required by InstallationTokenOptions.RepositoryIDs. It does so
+// by minting a short-lived, metadata-only bootstrap token and listing the repositories accessible to
+// the installation through it.
+func (a *app) resolveRepositoryIDs(installationID int64, names []string) ([]int64, error) {
+	bootstrap, _, err := a.client.CreateInstallationToken(context.TODO(), installationID, &github.InstallationTokenOptions{
+		Permissions: &github.InstallationPermissions{Metadata: github.String("read")},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create bootstrap token: %s", err)
+	}
+	client := a.installationClientFactory(bootstrap.GetToken())
+
+	remaining := make(map[string]bool, len(names))
+	for _, name := range names {
+		remaining[name] = true
+	}
+	var ids []int64
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		repos, resp, err := client.ListRepos(context.TODO(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("list repositories: %s", err)
+		}
+		for _, r := range repos.Repositories {
+			if remaining[r.GetName()] {
+				ids = append(ids, r.GetID())
+				delete(remaining, r.GetName())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	if len(remaining) > 0 {
+		return nil, fmt.Errorf("could not resolve repositories to IDs: %v", remaining)
+	}
+	return ids, nil
+}
+
+// findInstallationID returns the installation ID for the given owner (organization or user login),
+// caching the result since installation IDs do not change.
+func (a *app) findInstallationID(owner string) (int64, error) {
+	if id, ok := a.installationID[owner]; ok {
+		return id, nil
+	}
+	installations, _, err := a.client.ListInstallations(context.TODO(), &github.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("list installations: %s", err)
+	}
+	for _, i := range installations {
+		if i.GetAccount().GetLogin() == owner {
+			a.installationID[owner] = i.GetID()
+			return i.GetID(), nil
+		}
+	}
+	return 0, fmt.Errorf("no installation found for owner: %s", owner)
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	gogithub "github.com/google/go-github/v28/github"
+)
+
+// NewAppsClient returns an AppsAPI authenticated as the Github App identified by appID, signing
+// every request with a short-lived JWT derived from privateKeyPEM (the App's PEM-encoded RSA
+// private key). This is the client construction used to list installations and mint the
+// installation tokens that New's provider exchanges for access tokens/deploy keys.
+func NewAppsClient(appID int64, privateKeyPEM []byte) (AppsAPI, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parse github app private key: %s", err)
+	}
+	client := gogithub.NewClient(&http.Client{
+		Transport: &appTransport{appID: appID, key: key},
+	})
+	return client.Apps, nil
+}
+
+// appTransport signs each request with a JWT identifying the Github App, as required by every
+// endpoint used to authenticate as the app itself (as opposed to one of its installations).
+type appTransport struct {
+	appID int64
+	key   *rsa.PrivateKey
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	now := time.Now()
+	claims := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": t.appID,
+	})
+	signed, err := claims.SignedString(t.key)
+	if err != nil {
+		return nil, fmt.Errorf("sign github app jwt: %s", err)
+	}
+	signedReq := req.Clone(req.Context())
+	signedReq.Header.Set("Authorization", "Bearer "+signed)
+	signedReq.Header.Set("Accept", "application/vnd.github.machine-man-preview+json")
+	return http.DefaultTransport.RoundTrip(signedReq)
+}
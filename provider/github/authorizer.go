@@ -0,0 +1,53 @@
+package github
+
+import "fmt"
+
+// Policy describes which Github owners and repositories a namespace is permitted to request
+// credentials for.
+type Policy struct {
+	AllowedOwners       []string `json:"allowed_owners"`
+	AllowedRepositories []string `json:"allowed_repositories"`
+}
+
+// Authorizer verifies that a namespace is permitted to mint Github credentials for a given
+// owner/repository, as a pre-flight check before provider.Create runs. It is configured with a
+// mapping of namespace -> Policy, loaded alongside the requests config. Deploy key requests, and
+// access token requests that list repositories, are checked against both the owner and each
+// repository. An access token request that lists no repositories is checked against the owner
+// only, and the token it mints covers every repository of that owner's installation.
+type Authorizer struct {
+	policies map[string]*Policy
+}
+
+// NewAuthorizer returns a new Authorizer for the given namespace -> Policy mapping.
+func NewAuthorizer(policies map[string]*Policy) *Authorizer {
+	return &Authorizer{policies: policies}
+}
+
+// Authorize returns an error if namespace is not permitted to request credentials for owner/repository.
+// An empty repository, as is the case for access token requests that do not list any
+// repositories, only checks the owner.
+func (a *Authorizer) Authorize(namespace, owner, repository string) error {
+	policy, ok := a.policies[namespace]
+	if !ok {
+		return fmt.Errorf("namespace %q is not authorized to request any Github credentials", namespace)
+	}
+	if !matchesAny(policy.AllowedOwners, owner) {
+		return fmt.Errorf("namespace %q is not authorized to request credentials for owner %q", namespace, owner)
+	}
+	if repository != "" && !matchesAny(policy.AllowedRepositories, repository) {
+		return fmt.Errorf("namespace %q is not authorized to request credentials for repository %q", namespace, repository)
+	}
+	return nil
+}
+
+// matchesAny reports whether value is present in allowed, treating "*" as a wildcard that matches
+// any value.
+func matchesAny(allowed []string, value string) bool {
+	for _, a := range allowed {
+		if a == "*" || a == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package github
+
+import "testing"
+
+func TestAuthorizerAuthorize(t *testing.T) {
+	tests := []struct {
+		description string
+		policies    map[string]*Policy
+		namespace   string
+		owner       string
+		repository  string
+		wantErr     bool
+	}{
+		{
+			description: "allows an explicitly permitted owner and repository",
+			policies: map[string]*Policy{
+				"team-a": {AllowedOwners: []string{"acme"}, AllowedRepositories: []string{"api"}},
+			},
+			namespace:  "team-a",
+			owner:      "acme",
+			repository: "api",
+		},
+		{
+			description: "denies an owner that is not in the allow list",
+			policies: map[string]*Policy{
+				"team-a": {AllowedOwners: []string{"acme"}, AllowedRepositories: []string{"api"}},
+			},
+			namespace:  "team-a",
+			owner:      "other",
+			repository: "api",
+			wantErr:    true,
+		},
+		{
+			description: "denies a repository that is not in the allow list",
+			policies: map[string]*Policy{
+				"team-a": {AllowedOwners: []string{"acme"}, AllowedRepositories: []string{"api"}},
+			},
+			namespace:  "team-a",
+			owner:      "acme",
+			repository: "other",
+			wantErr:    true,
+		},
+		{
+			description: "denies a namespace with no configured policy",
+			policies:    map[string]*Policy{},
+			namespace:   "unknown",
+			owner:       "acme",
+			repository:  "api",
+			wantErr:     true,
+		},
+		{
+			description: "allows any owner and repository with a wildcard policy",
+			policies: map[string]*Policy{
+				"team-a": {AllowedOwners: []string{"*"}, AllowedRepositories: []string{"*"}},
+			},
+			namespace:  "team-a",
+			owner:      "acme",
+			repository: "api",
+		},
+		{
+			description: "skips the repository check for access token requests with no repository",
+			policies: map[string]*Policy{
+				"team-a": {AllowedOwners: []string{"acme"}, AllowedRepositories: []string{"api"}},
+			},
+			namespace:  "team-a",
+			owner:      "acme",
+			repository: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := NewAuthorizer(tc.policies).Authorize(tc.namespace, tc.owner, tc.repository)
+			if tc.wantErr && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got: %s", err)
+			}
+		})
+	}
+}
@@ -4,11 +4,7 @@ package github
 
 import (
 	"context"
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -17,7 +13,6 @@ import (
 	"github.com/telia-oss/sidecred"
 
 	"github.com/google/go-github/v28/github"
-	"golang.org/x/crypto/ssh"
 	"golang.org/x/oauth2"
 )
 
@@ -27,17 +22,32 @@ type DeployKeyRequestConfig struct {
 	Repository string `json:"repository"`
 	Title      string `json:"title"`
 	ReadOnly   bool   `json:"read_only"`
+
+	// KeyType is the key algorithm to generate: "rsa" (default), "ed25519" or "ecdsa".
+	KeyType string `json:"key_type"`
+
+	// KeyBits is the key size, interpreted according to KeyType: bits for "rsa" (default 2048),
+	// and curve size for "ecdsa" (256 or 384, default 256). Ignored for "ed25519".
+	KeyBits int `json:"key_bits"`
 }
 
 // AccessTokenRequestConfig ...
 type AccessTokenRequestConfig struct {
 	Owner string `json:"owner"`
+
+	// Permissions scopes the token down to the given set of Github App permissions
+	// (e.g. {"checks": "write"}), instead of the provider's default permission set.
+	Permissions map[string]string `json:"permissions"`
+
+	// Repositories scopes the token down to the given list of repositories, instead
+	// of every repository the installation has access to.
+	Repositories []string `json:"repositories"`
 }
 
 // New returns a new sidecred.Provider for Github credentials.
 func New(client AppsAPI, options ...option) sidecred.Provider {
 	p := &provider{
-		app:                 newApp(client),
+		app:                 newApp(client, defaultInstallationClientFactory),
 		keyRotationInterval: time.Duration(time.Hour * 24 * 7),
 		reposClientFactory:  defaultReposClientFactory,
 	}
@@ -63,6 +73,22 @@ func WithReposClientFactory(f func(token string) RepositoriesAPI) option {
 	}
 }
 
+// WithInstallationClientFactory sets the function used to create the installation-authenticated
+// clients used to resolve repository names to IDs, and can be used to return test fakes.
+func WithInstallationClientFactory(f func(token string) InstallationAPI) option {
+	return func(p *provider) {
+		p.app.installationClientFactory = f
+	}
+}
+
+// WithAuthorizer sets the Authorizer used to verify that a request's namespace is permitted to
+// request credentials for its owner/repository, before Create runs.
+func WithAuthorizer(a *Authorizer) option {
+	return func(p *provider) {
+		p.authorizer = a
+	}
+}
+
 func defaultReposClientFactory(token string) RepositoriesAPI {
 	oauth := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
@@ -71,11 +97,20 @@ func defaultReposClientFactory(token string) RepositoriesAPI {
 	return client.Repositories
 }
 
+func defaultInstallationClientFactory(token string) InstallationAPI {
+	oauth := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	))
+	client := github.NewClient(oauth)
+	return client.Apps
+}
+
 // Implements sidecred.Provider for Github Credentials.
 type provider struct {
 	app                 *app
 	reposClientFactory  func(token string) RepositoriesAPI
 	keyRotationInterval time.Duration
+	authorizer          *Authorizer
 }
 
 // Type implements sidecred.Provider.
@@ -85,6 +120,24 @@ func (p *provider) Type() sidecred.ProviderType {
 
 // Create implements sidecred.Provider.
 func (p *provider) Create(request *sidecred.Request) ([]*sidecred.Credential, *sidecred.Metadata, error) {
+	if p.authorizer != nil {
+		var c struct {
+			Owner        string   `json:"owner"`
+			Repository   string   `json:"repository"`
+			Repositories []string `json:"repositories"`
+		}
+		if err := request.UnmarshalConfig(&c); err != nil {
+			return nil, nil, err
+		}
+		if err := p.authorizer.Authorize(request.Namespace, c.Owner, c.Repository); err != nil {
+			return nil, nil, fmt.Errorf("authorize request: %s", err)
+		}
+		for _, repository := range c.Repositories {
+			if err := p.authorizer.Authorize(request.Namespace, c.Owner, repository); err != nil {
+				return nil, nil, fmt.Errorf("authorize request: %s", err)
+			}
+		}
+	}
 	switch request.Type {
 	case sidecred.GithubDeployKey:
 		return p.createDeployKey(request)
@@ -99,12 +152,15 @@ func (p *provider) createAccessToken(request *sidecred.Request) ([]*sidecred.Cre
 	if err := request.UnmarshalConfig(&c); err != nil {
 		return nil, nil, err
 	}
-	userToken, expiration, err := p.app.createInstallationToken(c.Owner, &github.InstallationPermissions{
-		Metadata:     github.String("read"),
-		Contents:     github.String("read"),
-		PullRequests: github.String("write"),
-		Statuses:     github.String("write"),
-	})
+	permissions := c.Permissions
+	if permissions == nil {
+		permissions = defaultPermissions()
+	}
+	installationPermissions, err := toInstallationPermissions(permissions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create access token: %s", err)
+	}
+	userToken, expiration, err := p.app.createInstallationToken(c.Owner, installationPermissions, c.Repositories)
 	if err != nil {
 		return nil, nil, fmt.Errorf("create access token: %s", err)
 	}
@@ -124,12 +180,12 @@ func (p *provider) createDeployKey(request *sidecred.Request) ([]*sidecred.Crede
 	adminToken, _, err := p.app.createInstallationToken(c.Owner, &github.InstallationPermissions{
 		Administration: github.String("write"), // Used to add deploy keys to repositories: https://developer.github.com/v3/apps/permissions/#permission-on-administration
 		Metadata:       github.String("read"),
-	})
+	}, []string{c.Repository})
 	if err != nil {
 		return nil, nil, fmt.Errorf("create administrator access token: %s", err)
 	}
 
-	privateKey, publicKey, err := p.generateKeyPair()
+	privateKey, publicKey, err := generateKeyPair(c.KeyType, c.KeyBits)
 	if err != nil {
 		return nil, nil, fmt.Errorf("generate key pair: %s", err)
 	}
@@ -145,7 +201,10 @@ func (p *provider) createDeployKey(request *sidecred.Request) ([]*sidecred.Crede
 		return nil, nil, fmt.Errorf("create deploy key: %s", err)
 	}
 
-	metadata := &sidecred.Metadata{"key_id": strconv.Itoa(int(key.GetID()))}
+	metadata := &sidecred.Metadata{
+		"key_id":   strconv.Itoa(int(key.GetID())),
+		"key_type": keyTypeOrDefault(c.KeyType),
+	}
 	return []*sidecred.Credential{{
 		Name:        c.Repository + "-deploy-key",
 		Value:       privateKey,
@@ -154,25 +213,6 @@ func (p *provider) createDeployKey(request *sidecred.Request) ([]*sidecred.Crede
 	}}, metadata, nil
 }
 
-func (p *provider) generateKeyPair() (string, string, error) {
-	key, err := rsa.GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		return "", "", err
-	}
-
-	privateKey := pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
-	})
-
-	pub, err := ssh.NewPublicKey(&key.PublicKey)
-	if err != nil {
-		return "", "", err
-	}
-	publicKey := ssh.MarshalAuthorizedKey(pub)
-	return string(privateKey), string(publicKey), nil
-}
-
 // Destroy implements sidecred.Provider.
 func (p *provider) Destroy(resource *sidecred.Resource) error {
 	var c DeployKeyRequestConfig
@@ -193,7 +233,7 @@ func (p *provider) Destroy(resource *sidecred.Resource) error {
 	adminToken, _, err := p.app.createInstallationToken(c.Owner, &github.InstallationPermissions{
 		Administration: github.String("write"), // Used to add deploy keys to repositories: https://developer.github.com/v3/apps/permissions/#permission-on-administration
 		Metadata:       github.String("read"),
-	})
+	}, []string{c.Repository})
 	if err != nil {
 		return fmt.Errorf("create administrator access token: %s", err)
 	}
@@ -0,0 +1,26 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v28/github"
+)
+
+// AppsAPI is the subset of the Github Apps API that is used by this provider.
+type AppsAPI interface {
+	ListInstallations(ctx context.Context, opts *github.ListOptions) ([]*github.Installation, *github.Response, error)
+	CreateInstallationToken(ctx context.Context, id int64, opts *github.InstallationTokenOptions) (*github.InstallationToken, *github.Response, error)
+}
+
+// RepositoriesAPI is the subset of the Github Repositories API that is used by this provider.
+type RepositoriesAPI interface {
+	CreateKey(ctx context.Context, owner, repo string, key *github.Key) (*github.Key, *github.Response, error)
+	DeleteKey(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+}
+
+// InstallationAPI is the subset of the Github Apps API that is authenticated as an installation
+// (rather than as the app itself), used to resolve repository names to the repository IDs required
+// to scope down an installation token to a subset of the installation's repositories.
+type InstallationAPI interface {
+	ListRepos(ctx context.Context, opts *github.ListOptions) (*github.ListRepositories, *github.Response, error)
+}
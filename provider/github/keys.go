@@ -0,0 +1,80 @@
+package github
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const defaultRSABits = 2048
+
+// keyTypeOrDefault returns keyType, defaulting to "rsa" for backwards compatibility with deploy keys
+// created before KeyType was introduced.
+func keyTypeOrDefault(keyType string) string {
+	if keyType == "" {
+		return "rsa"
+	}
+	return keyType
+}
+
+// generateKeyPair generates a key pair of the given type (returning the private key and the
+// SSH-authorized public key), for use as a deploy key.
+func generateKeyPair(keyType string, keyBits int) (string, string, error) {
+	switch keyTypeOrDefault(keyType) {
+	case "rsa":
+		bits := keyBits
+		if bits == 0 {
+			bits = defaultRSABits
+		}
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return "", "", err
+		}
+		privateKey := pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})
+		return marshalSSHPublicKey(privateKey, &key.PublicKey)
+	case "ed25519":
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+		block, err := ssh.MarshalPrivateKey(private, "")
+		if err != nil {
+			return "", "", err
+		}
+		return marshalSSHPublicKey(pem.EncodeToMemory(block), public)
+	case "ecdsa":
+		curve := elliptic.P256()
+		if keyBits > 256 {
+			curve = elliptic.P384()
+		}
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+		block, err := ssh.MarshalPrivateKey(key, "")
+		if err != nil {
+			return "", "", err
+		}
+		return marshalSSHPublicKey(pem.EncodeToMemory(block), &key.PublicKey)
+	default:
+		return "", "", fmt.Errorf("unsupported key type: %s", keyType)
+	}
+}
+
+func marshalSSHPublicKey(privateKey []byte, public interface{}) (string, string, error) {
+	pub, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return "", "", err
+	}
+	return string(privateKey), string(ssh.MarshalAuthorizedKey(pub)), nil
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	tests := []struct {
+		keyType string
+		keyBits int
+	}{
+		{keyType: "", keyBits: 0},
+		{keyType: "rsa", keyBits: 0},
+		{keyType: "ed25519", keyBits: 0},
+		{keyType: "ecdsa", keyBits: 0},
+		{keyType: "ecdsa", keyBits: 384},
+	}
+	for _, tc := range tests {
+		private, public, err := generateKeyPair(tc.keyType, tc.keyBits)
+		if err != nil {
+			t.Fatalf("generateKeyPair(%q, %d): unexpected error: %s", tc.keyType, tc.keyBits, err)
+		}
+		if private == "" || public == "" {
+			t.Fatalf("generateKeyPair(%q, %d): expected non-empty key material", tc.keyType, tc.keyBits)
+		}
+		if _, err := ssh.ParsePrivateKey([]byte(private)); err != nil {
+			t.Errorf("generateKeyPair(%q, %d): failed to parse private key: %s", tc.keyType, tc.keyBits, err)
+		}
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(public)); err != nil {
+			t.Errorf("generateKeyPair(%q, %d): failed to parse public key: %s", tc.keyType, tc.keyBits, err)
+		}
+	}
+}
+
+func TestGenerateKeyPairUnsupportedType(t *testing.T) {
+	if _, _, err := generateKeyPair("dsa", 0); err == nil {
+		t.Fatal("expected an error for an unsupported key type")
+	}
+}
+
+func TestKeyTypeOrDefault(t *testing.T) {
+	if got := keyTypeOrDefault(""); got != "rsa" {
+		t.Errorf("keyTypeOrDefault(\"\") = %q, want %q", got, "rsa")
+	}
+	if got := keyTypeOrDefault("ed25519"); got != "ed25519" {
+		t.Errorf("keyTypeOrDefault(\"ed25519\") = %q, want %q", got, "ed25519")
+	}
+}
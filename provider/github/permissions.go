@@ -0,0 +1,47 @@
+package github
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-github/v28/github"
+)
+
+// defaultPermissions are the permissions granted to access tokens when a request does not specify its own.
+func defaultPermissions() map[string]string {
+	return map[string]string{
+		"metadata":      "read",
+		"contents":      "read",
+		"pull_requests": "write",
+		"statuses":      "write",
+	}
+}
+
+// toInstallationPermissions converts a map of permission name (e.g. "pull_requests") to access level
+// (e.g. "write") into the github.InstallationPermissions struct expected by the Github Apps API.
+func toInstallationPermissions(permissions map[string]string) (*github.InstallationPermissions, error) {
+	out := &github.InstallationPermissions{}
+	v := reflect.ValueOf(out).Elem()
+	for name, level := range permissions {
+		field := v.FieldByName(toFieldName(name))
+		if !field.IsValid() || field.Kind() != reflect.Ptr {
+			return nil, fmt.Errorf("unknown permission: %s", name)
+		}
+		field.Set(reflect.ValueOf(github.String(level)))
+	}
+	return out, nil
+}
+
+// toFieldName converts a snake_case permission name (as used in the Github Apps API and in sidecred
+// request configs) to the CamelCase field name used by github.InstallationPermissions.
+func toFieldName(name string) string {
+	parts := strings.Split(name, "_")
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,51 @@
+package github
+
+import "testing"
+
+func TestToFieldName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "metadata", want: "Metadata"},
+		{name: "pull_requests", want: "PullRequests"},
+		{name: "checks", want: "Checks"},
+	}
+	for _, tc := range tests {
+		if got := toFieldName(tc.name); got != tc.want {
+			t.Errorf("toFieldName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestToInstallationPermissions(t *testing.T) {
+	permissions, err := toInstallationPermissions(map[string]string{
+		"checks":   "write",
+		"metadata": "read",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := permissions.GetChecks(); got != "write" {
+		t.Errorf("GetChecks() = %q, want %q", got, "write")
+	}
+	if got := permissions.GetMetadata(); got != "read" {
+		t.Errorf("GetMetadata() = %q, want %q", got, "read")
+	}
+}
+
+func TestToInstallationPermissionsUnknown(t *testing.T) {
+	if _, err := toInstallationPermissions(map[string]string{"not_a_real_permission": "write"}); err == nil {
+		t.Fatal("expected an error for an unknown permission")
+	}
+}
+
+func TestDefaultPermissions(t *testing.T) {
+	permissions, err := toInstallationPermissions(defaultPermissions())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := permissions.GetPullRequests(); got != "write" {
+		t.Errorf("GetPullRequests() = %q, want %q", got, "write")
+	}
+}
@@ -0,0 +1,48 @@
+package gitlab
+
+import "github.com/xanzy/go-gitlab"
+
+// NewClient returns a ClientAPI backed by a real GitLab API client, authenticated with
+// personalAccessToken against baseURL (e.g. "https://gitlab.com/api/v4" for GitLab.com, or a
+// self-managed instance's API root).
+func NewClient(baseURL, personalAccessToken string) (ClientAPI, error) {
+	inner, err := gitlab.NewClient(personalAccessToken, gitlab.WithBaseURL(baseURL))
+	if err != nil {
+		return nil, err
+	}
+	return &client{inner: inner}, nil
+}
+
+type client struct {
+	inner *gitlab.Client
+}
+
+// CreateProjectAccessToken implements ClientAPI.
+func (c *client) CreateProjectAccessToken(pid string, opts *gitlab.CreateProjectAccessTokenOptions) (*gitlab.ProjectAccessToken, *gitlab.Response, error) {
+	return c.inner.ProjectAccessTokens.CreateProjectAccessToken(pid, opts)
+}
+
+// RevokeProjectAccessToken implements ClientAPI.
+func (c *client) RevokeProjectAccessToken(pid string, id int) (*gitlab.Response, error) {
+	return c.inner.ProjectAccessTokens.RevokeProjectAccessToken(pid, id)
+}
+
+// CreateGroupAccessToken implements ClientAPI.
+func (c *client) CreateGroupAccessToken(gid string, opts *gitlab.CreateGroupAccessTokenOptions) (*gitlab.GroupAccessToken, *gitlab.Response, error) {
+	return c.inner.GroupAccessTokens.CreateGroupAccessToken(gid, opts)
+}
+
+// RevokeGroupAccessToken implements ClientAPI.
+func (c *client) RevokeGroupAccessToken(gid string, id int) (*gitlab.Response, error) {
+	return c.inner.GroupAccessTokens.RevokeGroupAccessToken(gid, id)
+}
+
+// AddDeployKey implements ClientAPI.
+func (c *client) AddDeployKey(pid string, opts *gitlab.AddDeployKeyOptions) (*gitlab.ProjectDeployKey, *gitlab.Response, error) {
+	return c.inner.DeployKeys.AddDeployKey(pid, opts)
+}
+
+// DeleteDeployKey implements ClientAPI.
+func (c *client) DeleteDeployKey(pid string, deployKey int) (*gitlab.Response, error) {
+	return c.inner.DeployKeys.DeleteDeployKey(pid, deployKey)
+}
@@ -0,0 +1,231 @@
+// Package gitlab implements a sidecred.Provider for GitLab project/group access tokens and deploy keys.
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/telia-oss/sidecred"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// AccessTokenRequestConfig ... Exactly one of ProjectID/GroupID must be set.
+type AccessTokenRequestConfig struct {
+	ProjectID   string   `json:"project_id"`
+	GroupID     string   `json:"group_id"`
+	Name        string   `json:"name"`
+	Scopes      []string `json:"scopes"`
+	AccessLevel string   `json:"access_level"`
+	ExpiresIn   string   `json:"expires_in"`
+}
+
+// DeployKeyRequestConfig ...
+type DeployKeyRequestConfig struct {
+	ProjectID string `json:"project_id"`
+	Title     string `json:"title"`
+	CanPush   bool   `json:"can_push"`
+}
+
+// New returns a new sidecred.Provider for GitLab credentials.
+func New(client ClientAPI, options ...option) sidecred.Provider {
+	p := &provider{
+		client:              client,
+		keyRotationInterval: time.Duration(time.Hour * 24 * 7),
+	}
+	for _, optionFunc := range options {
+		optionFunc(p)
+	}
+	return p
+}
+
+type option func(*provider)
+
+// WithDeployKeyRotationInterval sets the interval at which deploy keys should be rotated.
+func WithDeployKeyRotationInterval(duration time.Duration) option {
+	return func(p *provider) {
+		p.keyRotationInterval = duration
+	}
+}
+
+// Implements sidecred.Provider for GitLab credentials.
+type provider struct {
+	client              ClientAPI
+	keyRotationInterval time.Duration
+}
+
+// Type implements sidecred.Provider.
+func (p *provider) Type() sidecred.ProviderType {
+	return sidecred.Gitlab
+}
+
+// Create implements sidecred.Provider.
+func (p *provider) Create(request *sidecred.Request) ([]*sidecred.Credential, *sidecred.Metadata, error) {
+	switch request.Type {
+	case sidecred.GitlabAccessToken:
+		return p.createAccessToken(request)
+	case sidecred.GitlabDeployKey:
+		return p.createDeployKey(request)
+	}
+	return nil, nil, fmt.Errorf("invalid request: %s", request.Type)
+}
+
+// defaultAccessTokenExpiry is used when a request does not specify expires_in, since GitLab
+// requires project/group access tokens to have an expiration date.
+const defaultAccessTokenExpiry = time.Hour * 24 * 30
+
+func (p *provider) createAccessToken(request *sidecred.Request) ([]*sidecred.Credential, *sidecred.Metadata, error) {
+	var c AccessTokenRequestConfig
+	if err := request.UnmarshalConfig(&c); err != nil {
+		return nil, nil, err
+	}
+	expiration, err := expiresAt(c.ExpiresIn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse expires_in: %s", err)
+	}
+	expiresAt := gitlab.ISOTime(expiration)
+	if c.ProjectID != "" && c.GroupID != "" {
+		return nil, nil, fmt.Errorf("exactly one of project_id or group_id must be set")
+	}
+
+	var (
+		token *string
+		id    int
+		scope string
+	)
+	switch {
+	case c.ProjectID != "":
+		scope = c.ProjectID
+		t, _, err := p.client.CreateProjectAccessToken(c.ProjectID, &gitlab.CreateProjectAccessTokenOptions{
+			Name:        &c.Name,
+			Scopes:      &c.Scopes,
+			AccessLevel: accessLevel(c.AccessLevel),
+			ExpiresAt:   &expiresAt,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("create project access token: %s", err)
+		}
+		token, id = &t.Token, t.ID
+	case c.GroupID != "":
+		scope = c.GroupID
+		t, _, err := p.client.CreateGroupAccessToken(c.GroupID, &gitlab.CreateGroupAccessTokenOptions{
+			Name:        &c.Name,
+			Scopes:      &c.Scopes,
+			AccessLevel: accessLevel(c.AccessLevel),
+			ExpiresAt:   &expiresAt,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("create group access token: %s", err)
+		}
+		token, id = &t.Token, t.ID
+	default:
+		return nil, nil, fmt.Errorf("exactly one of project_id or group_id must be set")
+	}
+
+	metadata := &sidecred.Metadata{
+		"token_id": strconv.Itoa(id),
+		"scope":    scope,
+	}
+	return []*sidecred.Credential{{
+		Name:        scope + "-access-token",
+		Value:       *token,
+		Description: "GitLab access token managed by sidecred.",
+		Expiration:  expiration,
+	}}, metadata, nil
+}
+
+// expiresAt parses expiresIn (a Go duration string, e.g. "720h") into an absolute expiration time,
+// falling back to defaultAccessTokenExpiry when it is empty.
+func expiresAt(expiresIn string) (time.Time, error) {
+	duration := defaultAccessTokenExpiry
+	if expiresIn != "" {
+		d, err := time.ParseDuration(expiresIn)
+		if err != nil {
+			return time.Time{}, err
+		}
+		duration = d
+	}
+	return time.Now().Add(duration).UTC(), nil
+}
+
+func (p *provider) createDeployKey(request *sidecred.Request) ([]*sidecred.Credential, *sidecred.Metadata, error) {
+	var c DeployKeyRequestConfig
+	if err := request.UnmarshalConfig(&c); err != nil {
+		return nil, nil, err
+	}
+	private, public, err := generateKeyPair()
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate key pair: %s", err)
+	}
+	key, _, err := p.client.AddDeployKey(c.ProjectID, &gitlab.AddDeployKeyOptions{
+		Title:   &c.Title,
+		Key:     &public,
+		CanPush: &c.CanPush,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("add deploy key: %s", err)
+	}
+	metadata := &sidecred.Metadata{"key_id": strconv.Itoa(key.ID)}
+	return []*sidecred.Credential{{
+		Name:        c.ProjectID + "-deploy-key",
+		Value:       private,
+		Description: "GitLab deploy key managed by sidecred.",
+		Expiration:  time.Now().Add(p.keyRotationInterval).UTC(),
+	}}, metadata, nil
+}
+
+// Destroy implements sidecred.Provider.
+func (p *provider) Destroy(resource *sidecred.Resource) error {
+	if resource.Metadata == nil {
+		return nil
+	}
+	switch resource.Type {
+	case sidecred.GitlabAccessToken:
+		var c AccessTokenRequestConfig
+		if err := json.Unmarshal(resource.Config, &c); err != nil {
+			return fmt.Errorf("unmarshal resource config: %s", err)
+		}
+		id, err := strconv.Atoi((*resource.Metadata)["token_id"])
+		if err != nil {
+			return fmt.Errorf("failed to convert token id to int: %s", err)
+		}
+		if c.ProjectID != "" {
+			_, err = p.client.RevokeProjectAccessToken(c.ProjectID, id)
+		} else {
+			_, err = p.client.RevokeGroupAccessToken(c.GroupID, id)
+		}
+		if err != nil {
+			return fmt.Errorf("revoke access token: %s", err)
+		}
+	case sidecred.GitlabDeployKey:
+		var c DeployKeyRequestConfig
+		if err := json.Unmarshal(resource.Config, &c); err != nil {
+			return fmt.Errorf("unmarshal resource config: %s", err)
+		}
+		keyID, err := strconv.Atoi((*resource.Metadata)["key_id"])
+		if err != nil {
+			return fmt.Errorf("failed to convert key id to int: %s", err)
+		}
+		if _, err := p.client.DeleteDeployKey(c.ProjectID, keyID); err != nil {
+			return fmt.Errorf("delete deploy key: %s", err)
+		}
+	}
+	return nil
+}
+
+func accessLevel(s string) *gitlab.AccessLevelValue {
+	levels := map[string]gitlab.AccessLevelValue{
+		"guest":      gitlab.GuestPermissions,
+		"reporter":   gitlab.ReporterPermissions,
+		"developer":  gitlab.DeveloperPermissions,
+		"maintainer": gitlab.MaintainerPermissions,
+		"owner":      gitlab.OwnerPermission,
+	}
+	if level, ok := levels[s]; ok {
+		return &level
+	}
+	level := gitlab.DeveloperPermissions
+	return &level
+}
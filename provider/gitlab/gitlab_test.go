@@ -0,0 +1,102 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/telia-oss/sidecred"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+type fakeClient struct {
+	createProjectAccessToken func(pid string, opts *gitlab.CreateProjectAccessTokenOptions) (*gitlab.ProjectAccessToken, *gitlab.Response, error)
+	revokeProjectAccessToken func(pid string, id int) (*gitlab.Response, error)
+	createGroupAccessToken   func(gid string, opts *gitlab.CreateGroupAccessTokenOptions) (*gitlab.GroupAccessToken, *gitlab.Response, error)
+	revokeGroupAccessToken   func(gid string, id int) (*gitlab.Response, error)
+	addDeployKey             func(pid string, opts *gitlab.AddDeployKeyOptions) (*gitlab.ProjectDeployKey, *gitlab.Response, error)
+	deleteDeployKey          func(pid string, deployKey int) (*gitlab.Response, error)
+}
+
+func (f *fakeClient) CreateProjectAccessToken(pid string, opts *gitlab.CreateProjectAccessTokenOptions) (*gitlab.ProjectAccessToken, *gitlab.Response, error) {
+	return f.createProjectAccessToken(pid, opts)
+}
+
+func (f *fakeClient) RevokeProjectAccessToken(pid string, id int) (*gitlab.Response, error) {
+	return f.revokeProjectAccessToken(pid, id)
+}
+
+func (f *fakeClient) CreateGroupAccessToken(gid string, opts *gitlab.CreateGroupAccessTokenOptions) (*gitlab.GroupAccessToken, *gitlab.Response, error) {
+	return f.createGroupAccessToken(gid, opts)
+}
+
+func (f *fakeClient) RevokeGroupAccessToken(gid string, id int) (*gitlab.Response, error) {
+	return f.revokeGroupAccessToken(gid, id)
+}
+
+func (f *fakeClient) AddDeployKey(pid string, opts *gitlab.AddDeployKeyOptions) (*gitlab.ProjectDeployKey, *gitlab.Response, error) {
+	return f.addDeployKey(pid, opts)
+}
+
+func (f *fakeClient) DeleteDeployKey(pid string, deployKey int) (*gitlab.Response, error) {
+	return f.deleteDeployKey(pid, deployKey)
+}
+
+func TestProviderType(t *testing.T) {
+	p := New(&fakeClient{})
+	if p.Type() != sidecred.Gitlab {
+		t.Errorf("Type() = %s, want %s", p.Type(), sidecred.Gitlab)
+	}
+}
+
+func TestCreateAccessTokenSetsExpiration(t *testing.T) {
+	var gotOpts *gitlab.CreateProjectAccessTokenOptions
+	client := &fakeClient{
+		createProjectAccessToken: func(pid string, opts *gitlab.CreateProjectAccessTokenOptions) (*gitlab.ProjectAccessToken, *gitlab.Response, error) {
+			gotOpts = opts
+			return &gitlab.ProjectAccessToken{ID: 1, Token: "token"}, &gitlab.Response{}, nil
+		},
+	}
+	p := New(client)
+
+	config, _ := json.Marshal(&AccessTokenRequestConfig{ProjectID: "123", Name: "ci", ExpiresIn: "24h"})
+	request := &sidecred.Request{Type: sidecred.GitlabAccessToken, Config: config}
+
+	creds, metadata, err := p.Create(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotOpts.ExpiresAt == nil {
+		t.Fatal("expected ExpiresAt to be set")
+	}
+	if creds[0].Expiration.IsZero() {
+		t.Error("expected credential Expiration to be set")
+	}
+	if (*metadata)["token_id"] != "1" {
+		t.Errorf("token_id = %s, want 1", (*metadata)["token_id"])
+	}
+}
+
+func TestDestroyDispatchesOnResourceType(t *testing.T) {
+	var revoked bool
+	client := &fakeClient{
+		revokeProjectAccessToken: func(pid string, id int) (*gitlab.Response, error) {
+			revoked = true
+			return &gitlab.Response{}, nil
+		},
+	}
+	p := New(client)
+
+	config, _ := json.Marshal(&AccessTokenRequestConfig{ProjectID: "123"})
+	resource := &sidecred.Resource{
+		Type:     sidecred.GitlabAccessToken,
+		Config:   config,
+		Metadata: &sidecred.Metadata{"token_id": "1"},
+	}
+	if err := p.Destroy(resource); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !revoked {
+		t.Error("expected RevokeProjectAccessToken to be called")
+	}
+}
@@ -0,0 +1,15 @@
+package gitlab
+
+import "github.com/xanzy/go-gitlab"
+
+// ClientAPI is the subset of the GitLab REST API that is used by this provider.
+type ClientAPI interface {
+	CreateProjectAccessToken(pid string, opts *gitlab.CreateProjectAccessTokenOptions) (*gitlab.ProjectAccessToken, *gitlab.Response, error)
+	RevokeProjectAccessToken(pid string, id int) (*gitlab.Response, error)
+
+	CreateGroupAccessToken(gid string, opts *gitlab.CreateGroupAccessTokenOptions) (*gitlab.GroupAccessToken, *gitlab.Response, error)
+	RevokeGroupAccessToken(gid string, id int) (*gitlab.Response, error)
+
+	AddDeployKey(pid string, opts *gitlab.AddDeployKeyOptions) (*gitlab.ProjectDeployKey, *gitlab.Response, error)
+	DeleteDeployKey(pid string, deployKey int) (*gitlab.Response, error)
+}
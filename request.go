@@ -0,0 +1,17 @@
+package sidecred
+
+import "encoding/json"
+
+// Request describes a single credential that a namespace wants created, identified by Name within
+// that Namespace, scoped to a ProviderType and configured via a provider-specific Config payload.
+type Request struct {
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Type      ProviderType    `json:"type"`
+	Config    json.RawMessage `json:"config"`
+}
+
+// UnmarshalConfig decodes Config into v, the provider-specific request config type.
+func (r *Request) UnmarshalConfig(v interface{}) error {
+	return json.Unmarshal(r.Config, v)
+}
@@ -0,0 +1,29 @@
+package sidecred
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Resource records the state of a single credential created by a Provider, so that subsequent
+// reconciliations can detect when it needs to be rotated or destroyed.
+type Resource struct {
+	ID         string          `json:"id"`
+	Namespace  string          `json:"namespace"`
+	Type       ProviderType    `json:"type"`
+	Config     json.RawMessage `json:"config"`
+	Metadata   *Metadata       `json:"metadata,omitempty"`
+	Expiration time.Time       `json:"expiration"`
+}
+
+// Credential is a single secret value produced by a Provider's Create call.
+type Credential struct {
+	Name        string    `json:"name"`
+	Value       string    `json:"value"`
+	Description string    `json:"description"`
+	Expiration  time.Time `json:"expiration"`
+}
+
+// Metadata is provider-specific bookkeeping persisted alongside a Resource (e.g. the upstream ID
+// needed to destroy or rotate the credential it tracks).
+type Metadata map[string]string
@@ -0,0 +1,99 @@
+// Package sidecred implements the core reconciliation loop: given a set of Requests and the
+// Providers registered to fulfil them, it creates missing or expired credentials and destroys ones
+// that are no longer requested, tracking the result in a State.
+package sidecred
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sidecred reconciles Requests against the Providers it was constructed with.
+type Sidecred struct {
+	providers map[ProviderType]Provider
+}
+
+// New returns a new Sidecred that dispatches to providers, keyed by each one's Type().
+func New(providers ...Provider) *Sidecred {
+	s := &Sidecred{providers: make(map[ProviderType]Provider, len(providers))}
+	for _, p := range providers {
+		s.providers[p.Type()] = p
+	}
+	return s
+}
+
+// Process reconciles requests against state: resources that are no longer requested, or whose
+// credential has expired, are destroyed; requests with no live resource are (re)created. The
+// reconciled set of resources is written back into state.
+func (s *Sidecred) Process(namespace string, requests []*Request, state *State) error {
+	wanted := make(map[string]*Request, len(requests))
+	for _, request := range requests {
+		request.Namespace = namespace
+		wanted[resourceID(namespace, request.Type, request.Name)] = request
+	}
+
+	now := time.Now()
+	var kept []*Resource
+	for _, resource := range state.Resources {
+		if _, ok := wanted[resource.ID]; ok && now.Before(resource.Expiration) {
+			kept = append(kept, resource)
+			delete(wanted, resource.ID)
+			continue
+		}
+		provider, err := s.providerFor(resource.Type)
+		if err != nil {
+			return err
+		}
+		if err := provider.Destroy(resource); err != nil {
+			return fmt.Errorf("destroy %s: %s", resource.ID, err)
+		}
+	}
+
+	for id, request := range wanted {
+		provider, err := s.providerFor(request.Type)
+		if err != nil {
+			return err
+		}
+		credentials, metadata, err := provider.Create(request)
+		if err != nil {
+			return fmt.Errorf("create %s: %s", id, err)
+		}
+		for _, credential := range credentials {
+			kept = append(kept, &Resource{
+				ID:         id,
+				Namespace:  namespace,
+				Type:       request.Type,
+				Config:     request.Config,
+				Metadata:   metadata,
+				Expiration: credential.Expiration,
+			})
+		}
+	}
+	state.Resources = kept
+	return nil
+}
+
+func (s *Sidecred) providerFor(t ProviderType) (Provider, error) {
+	provider, ok := s.providers[coarseType(t)]
+	if !ok {
+		return nil, fmt.Errorf("no provider registered for %q", t)
+	}
+	return provider, nil
+}
+
+// coarseType maps a Request/Resource's fine-grained ProviderType to the coarse type that its
+// Provider reports from Type(), e.g. GithubDeployKey/GithubAccessToken both route to Github.
+func coarseType(t ProviderType) ProviderType {
+	switch t {
+	case GithubAccessToken, GithubDeployKey:
+		return Github
+	case GitlabAccessToken, GitlabDeployKey:
+		return Gitlab
+	default:
+		return t
+	}
+}
+
+func resourceID(namespace string, t ProviderType, name string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, t, name)
+}
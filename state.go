@@ -0,0 +1,20 @@
+package sidecred
+
+// State is the full set of Resources currently tracked, persisted by a StateBackend between
+// reconciliations.
+type State struct {
+	Resources []*Resource `json:"resources"`
+}
+
+// StateBackend loads and saves a Sidecred's persisted State.
+type StateBackend interface {
+	Load(path string) (*State, error)
+	Save(path string, state *State) error
+}
+
+// ConfigBackend loads the Requests that should be reconciled, from wherever they're stored
+// (e.g. S3, GCS, a local file). Parallel to StateBackend, but for the read-only request config
+// rather than the read/write state.
+type ConfigBackend interface {
+	Load(path string) ([]*Request, error)
+}